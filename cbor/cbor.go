@@ -0,0 +1,12 @@
+// Package cbor provides a denoue.Encoder that emits RFC 8949 CBOR
+// records instead of the default JSON text, for high-throughput
+// services where smaller, faster-to-parse log records matter more
+// than human readability.
+package cbor
+
+import "github.com/Eratosthenes/denoue"
+
+// NewEncoder returns an Encoder suitable for JLog.SetEncoder.
+func NewEncoder() denoue.Encoder {
+	return denoue.NewCBOREncoder()
+}
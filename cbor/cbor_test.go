@@ -0,0 +1,269 @@
+package cbor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Eratosthenes/denoue"
+)
+
+// Test_Encode_majorsAndLengths checks the encoder's RFC 8949 byte layout
+// directly: map (major 5), text string (major 3) in the short (<24)
+// length form, unsigned int (major 0), and array (major 4). The one-byte
+// (24-255) length form is checked by Test_Encode_longTextUsesOneByteLength.
+func Test_Encode_majorsAndLengths(t *testing.T) {
+	dict := denoue.NewJDict()
+	dict.SetPair("a", "bb")
+	dict.Set(denoue.NewJInt("n", 10))
+	arr := denoue.NewJArray("arr")
+	arr.Add("x")
+	dict.Set(arr)
+
+	got, err := NewEncoder().Encode(dict)
+	if err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	// map(3 keys): major 5, 3 entries -> 0xa3
+	if got[0] != 0xa3 {
+		t.Fatalf("expected map header 0xa3, got 0x%02x", got[0])
+	}
+
+	// key "a" -> text(1) 'a': major 3, length 1 -> 0x61 'a'
+	rest := got[1:]
+	if rest[0] != 0x61 || rest[1] != 'a' {
+		t.Fatalf("expected text(1) 'a', got % x", rest[:2])
+	}
+
+	// val "bb" -> text(2) 'bb': 0x62 'b' 'b'
+	rest = rest[2:]
+	if rest[0] != 0x62 || string(rest[1:3]) != "bb" {
+		t.Fatalf("expected text(2) 'bb', got % x", rest[:3])
+	}
+
+	// key "arr" -> text(3), then array(1) holding text(1) 'x'; key "n" ->
+	// text(1), then unsigned int 10 in the short form (major 0).
+	rest = rest[3:]
+	want := []byte{0x63, 'a', 'r', 'r', 4<<5 | 1, 0x61, 'x', 0x61, 'n', 10}
+	if !bytes.Equal(rest, want) {
+		t.Fatalf("expected array and uint entries % x, got % x", want, rest)
+	}
+}
+
+// Test_Encode_longTextUsesOneByteLength checks that a string 24 bytes or
+// longer (the smallest length that no longer fits in the 5-bit
+// additional-info field) switches to the one-byte length form.
+func Test_Encode_longTextUsesOneByteLength(t *testing.T) {
+	val := strings.Repeat("x", 24)
+	dict := denoue.NewJDict()
+	dict.SetPair("k", val)
+
+	got, err := NewEncoder().Encode(dict)
+	if err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	// map(1), key "k" (text(1)): 0xa1 0x61 'k', then the 24-byte value.
+	valHead := got[3:5]
+	if valHead[0] != 0x78 || valHead[1] != 24 {
+		t.Fatalf("expected text major with one-byte length (0x78, 24), got % x", valHead)
+	}
+}
+
+// Test_Encode_negativeInt checks major 1 (negative integers), encoded as
+// -(n+1) per RFC 8949.
+func Test_Encode_negativeInt(t *testing.T) {
+	dict := denoue.NewJDict()
+	dict.Set(denoue.NewJInt("n", -5))
+
+	got, err := NewEncoder().Encode(dict)
+	if err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	// map(1), key "n" (text(1) -> 0x61 'n'), then major 1 with value 4 (-5 -> -(4+1)).
+	want := []byte{0xa1, 0x61, 'n', 1<<5 | 4}
+	if len(got) != len(want) {
+		t.Fatalf("got %d bytes, want %d: % x vs % x", len(got), len(want), got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("byte %d: got 0x%02x, want 0x%02x (% x)", i, got[i], want[i], got)
+		}
+	}
+}
+
+// Test_Encode_doesNotDoubleEscapeQuotes guards against JSON-text escaping
+// (MakeSafe/AddSafe) being baked into JPair.Val/JArray.Vals/ByteVals at
+// construction time: since CBOR text/byte strings have no backslash-escape
+// convention, a pre-escaped value would round-trip with spurious literal
+// backslashes. denoue.JLog.Error stores err.Error() verbatim precisely so
+// CBOR sees the original bytes; this checks the encoder side of that
+// with an "error" JPair set directly on a JDict.
+func Test_Encode_doesNotDoubleEscapeQuotes(t *testing.T) {
+	dict := denoue.NewJDict()
+	dict.SetPair("error", `say "hi"`)
+
+	got, err := NewEncoder().Encode(dict)
+	if err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	// map(1), key "error" (text(5)), then text(8) 'say "hi"' verbatim —
+	// no backslashes, and the original 8 bytes, not 10.
+	want := []byte{0xa1, 3<<5 | 5}
+	want = append(want, "error"...)
+	want = append(want, 3<<5|8)
+	want = append(want, `say "hi"`...)
+	if !bytes.Equal(got, want) {
+		t.Fatalf("got % x, want % x", got, want)
+	}
+}
+
+// Test_Encode_boolPair checks major 7 simple values for JBoolPair: true
+// (additional info 21) and false (additional info 20) are distinct, so a
+// swapped encoding wouldn't go unnoticed.
+func Test_Encode_boolPair(t *testing.T) {
+	for _, tc := range []struct {
+		val  bool
+		want byte
+	}{
+		{true, 7<<5 | 21},
+		{false, 7<<5 | 20},
+	} {
+		dict := denoue.NewJDict()
+		dict.Set(denoue.NewJBool("b", tc.val))
+
+		got, err := NewEncoder().Encode(dict)
+		if err != nil {
+			t.Fatalf("Encode returned error: %v", err)
+		}
+
+		want := []byte{0xa1, 0x61, 'b', tc.want}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("JBoolPair(%v): got % x, want % x", tc.val, got, want)
+		}
+	}
+}
+
+// Test_Encode_floatPair checks major 7 additional info 27 (double-precision
+// float) for JFloatPair, with the value's IEEE 754 bits written big-endian.
+func Test_Encode_floatPair(t *testing.T) {
+	dict := denoue.NewJDict()
+	dict.Set(denoue.NewJFloat("f", 2.5))
+
+	got, err := NewEncoder().Encode(dict)
+	if err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	want := []byte{0xa1, 0x61, 'f', 7<<5 | 27}
+	var bits [8]byte
+	binary.BigEndian.PutUint64(bits[:], math.Float64bits(2.5))
+	want = append(want, bits[:]...)
+	if !bytes.Equal(got, want) {
+		t.Fatalf("got % x, want % x", got, want)
+	}
+}
+
+// Test_Encode_timePair checks that JTimePair renders as a CBOR text
+// string (major 3) of the value formatted with its layout, not a
+// timestamp major type.
+func Test_Encode_timePair(t *testing.T) {
+	layout := "2006-01-02"
+	val := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+	dict := denoue.NewJDict()
+	dict.Set(denoue.NewJTime("t", val, layout))
+
+	got, err := NewEncoder().Encode(dict)
+	if err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	formatted := val.Format(layout)
+	want := []byte{0xa1, 0x61, 't', 3<<5 | byte(len(formatted))}
+	want = append(want, formatted...)
+	if !bytes.Equal(got, want) {
+		t.Fatalf("got % x, want % x", got, want)
+	}
+}
+
+// Test_Encode_rawPair checks that JRawPair renders as a CBOR byte string
+// (major 2) holding Raw verbatim, not re-encoded as text.
+func Test_Encode_rawPair(t *testing.T) {
+	raw := []byte(`{"nested":true}`)
+	dict := denoue.NewJDict()
+	dict.Set(denoue.NewJRaw("r", raw))
+
+	got, err := NewEncoder().Encode(dict)
+	if err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	want := []byte{0xa1, 0x61, 'r', 2<<5 | byte(len(raw))}
+	want = append(want, raw...)
+	if !bytes.Equal(got, want) {
+		t.Fatalf("got % x, want % x", got, want)
+	}
+}
+
+// Test_Encode_group checks that a JGroup recurses into a nested CBOR map
+// (major 5) rather than being flattened or stringified.
+func Test_Encode_group(t *testing.T) {
+	inner := denoue.NewJDict()
+	inner.SetPair("method", "GET")
+	dict := denoue.NewJDict()
+	dict.Set(denoue.NewJGroup("request", inner))
+
+	got, err := NewEncoder().Encode(dict)
+	if err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	want := []byte{0xa1, 3<<5 | 7}
+	want = append(want, "request"...)
+	want = append(want, 0xa1, 3<<5|6)
+	want = append(want, "method"...)
+	want = append(want, 3<<5|3)
+	want = append(want, "GET"...)
+	if !bytes.Equal(got, want) {
+		t.Fatalf("got % x, want % x", got, want)
+	}
+}
+
+// Test_Encode_arrayTypedVals checks the JArray branches cborWriteArray
+// doesn't share with the basic Vals case covered above:
+// ByteVals (major 2), FloatVals (major 7/27), and BoolVals (major 7/20
+// or 21), all within the same array.
+func Test_Encode_arrayTypedVals(t *testing.T) {
+	arr := denoue.JArray{Key: "arr"}
+	arr.ByteVals = [][]byte{[]byte("hi")}
+	arr.AddFloat(2.5)
+	arr.AddBool(false)
+
+	dict := denoue.NewJDict()
+	dict.Set(arr)
+
+	got, err := NewEncoder().Encode(dict)
+	if err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	want := []byte{0xa1, 3<<5 | 3}
+	want = append(want, "arr"...)
+	want = append(want, 4<<5|3) // array(3)
+	want = append(want, 2<<5|2)
+	want = append(want, "hi"...)
+	want = append(want, 7<<5|27)
+	var bits [8]byte
+	binary.BigEndian.PutUint64(bits[:], math.Float64bits(2.5))
+	want = append(want, bits[:]...)
+	want = append(want, 7<<5|20)
+	if !bytes.Equal(got, want) {
+		t.Fatalf("got % x, want % x", got, want)
+	}
+}
@@ -0,0 +1,28 @@
+package denoue
+
+import "context"
+
+type ctxKey struct{}
+
+// WithContext returns a copy of ctx carrying j, so a logger pre-populated
+// with fields (request id, remote_ip, caller) can be threaded through a
+// call graph and enriched by downstream code via FromContext. Following
+// zerolog's ctx-cow pattern, the context is only replaced if it doesn't
+// already carry j, so a sub-handler can cheaply shadow a parent's logger
+// (e.g. one returned by With()) without disturbing callers still holding
+// the original ctx.
+func (j *JLog) WithContext(ctx context.Context) context.Context {
+	if existing, ok := ctx.Value(ctxKey{}).(*JLog); ok && existing == j {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxKey{}, j)
+}
+
+// FromContext retrieves the *JLog carried by ctx, or a fresh logger if
+// none was set.
+func FromContext(ctx context.Context) *JLog {
+	if j, ok := ctx.Value(ctxKey{}).(*JLog); ok {
+		return j
+	}
+	return New()
+}
@@ -4,13 +4,11 @@
 package denoue
 
 import (
-	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"os"
-	"os/exec"
 	"sync"
 	"time"
 )
@@ -22,23 +20,42 @@ type JLogger interface {
 	Set(elem JObject)
 	SetOutput(out io.Writer)
 	SetTime(timeLayout string)
+	SetEncoder(enc Encoder)
 	Print()
 	Reset()
 	PrettyPrint()
 	Info(format string, args ...string)
 	Warn(format string, args ...string)
 	Error(err error)
+	ErrorStack(err error)
 	Log(f LogFunc, err error, args ...string)
+	InfoEvent() *Event
+	WarnEvent() *Event
+	ErrorEvent(err error) *Event
+	With() *JLog
+	WithContext(ctx context.Context) context.Context
+	SetStack(enable bool)
+	SetStackMarshaler(m StackMarshaler)
+	AddHook(h Hook)
+	SetOnHookError(f func(error))
 }
 
 type JLog struct {
-	out        io.Writer
-	msgs       JArray
-	level      string
-	timeLayout string
-	objects    map[string]JObject
-	once       *sync.Once
-	mu         *sync.Mutex
+	out            io.Writer
+	msgs           JArray
+	level          string
+	timeLayout     string
+	objects        map[string]JObject
+	objectsShared  bool // objects may be aliased with another logger; see With
+	msgsShared     bool // msgs' slices may be aliased with another logger; see With
+	encoder        Encoder
+	enableStack    bool
+	stackMarshaler StackMarshaler
+	hooks          []Hook
+	hooksShared    bool // hooks may be aliased with another logger; see With
+	onHookError    func(error)
+	once           *sync.Once
+	mu             *sync.Mutex
 }
 
 // New creates a new json logger.
@@ -48,6 +65,7 @@ func New() *JLog {
 		msgs:       JArray{Key: MSG_KEY},
 		level:      INFO,
 		objects:    make(map[string]JObject),
+		encoder:    jsonEncoder{},
 		once:       new(sync.Once),
 		mu:         new(sync.Mutex),
 		timeLayout: DEFAULT_TIME_LAYOUT,
@@ -67,7 +85,7 @@ func (j *JLog) Get(k string) (JObject, error) {
 }
 
 type KeyVal interface {
-	JPair | JArray | JGroup
+	JPair | JArray | JGroup | JIntPair | JFloatPair | JBoolPair | JTimePair | JRawPair
 }
 
 // Get retrieves some JSON object that can be looked up by key.
@@ -93,6 +111,7 @@ func (j *JLog) Pop(k string) (JObject, error) {
 	if !ok {
 		return nil, errors.New("key not found")
 	}
+	j.cowObjects()
 	delete(j.objects, k)
 	return obj, nil
 }
@@ -102,6 +121,7 @@ func (j *JLog) SetPair(key, val string) {
 	j.mu.Lock()
 	defer j.mu.Unlock()
 
+	j.cowObjects()
 	pair := JPair{Key: key, Val: val}
 	j.objects[pair.GetKey()] = pair
 }
@@ -111,9 +131,59 @@ func (j *JLog) Set(elem JObject) {
 	j.mu.Lock()
 	defer j.mu.Unlock()
 
+	j.cowObjects()
 	j.objects[elem.GetKey()] = elem
 }
 
+// cowObjects clones j.objects if it may still be aliased with another
+// logger (see With), so that mutating one logger's fields never leaks
+// into the other. Callers must hold j.mu.
+func (j *JLog) cowObjects() {
+	if !j.objectsShared {
+		return
+	}
+
+	cp := make(map[string]JObject, len(j.objects))
+	for k, v := range j.objects {
+		cp[k] = v
+	}
+	j.objects = cp
+	j.objectsShared = false
+}
+
+// cowMsgs clones j.msgs if its backing slices may still be aliased with
+// another logger (see With), so that appending a message on one logger
+// never overwrites or leaks into the other. Callers must hold j.mu.
+func (j *JLog) cowMsgs() {
+	if !j.msgsShared {
+		return
+	}
+
+	j.msgs = j.msgs.clone()
+	j.msgsShared = false
+}
+
+// With returns a shallow copy of j with its own mutex and once-guard,
+// suitable for attaching request-scoped fields without affecting j. The
+// copy's objects map, msgs slices, and hooks slice are shared with j
+// until either logger is mutated (Set/SetPair/Pop/Error/Log, Info/Warn,
+// or AddHook), at which point they're copied-on-write.
+func (j *JLog) With() *JLog {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	cp := *j
+	cp.mu = new(sync.Mutex)
+	cp.once = new(sync.Once)
+	j.objectsShared = true
+	cp.objectsShared = true
+	j.msgsShared = true
+	cp.msgsShared = true
+	j.hooksShared = true
+	cp.hooksShared = true
+	return &cp
+}
+
 // SetOutput sets the output.
 func (j *JLog) SetOutput(out io.Writer) {
 	j.mu.Lock()
@@ -130,6 +200,25 @@ func (j *JLog) SetTime(timeLayout string) {
 	j.timeLayout = timeLayout
 }
 
+// SetEncoder swaps the Encoder used by Print, e.g. to switch from the
+// default JSON text output to CBOR via denoue/cbor.NewEncoder().
+func (j *JLog) SetEncoder(enc Encoder) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.encoder = enc
+}
+
+// snapshotWriter returns copies of j.encoder and j.out, so Print and
+// Event.send can encode/write without racing a concurrent
+// SetEncoder/SetOutput.
+func (j *JLog) snapshotWriter() (Encoder, io.Writer) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.encoder, j.out
+}
+
 // Reset allows you to print more than once (for debugging).
 func (j *JLog) Reset() {
 	j.once = new(sync.Once)
@@ -151,17 +240,21 @@ func (j *JLog) Print() {
 			dict.Set(elem)
 		}
 
-		// instead of this:
-		// fmt.Fprintf(j.out, "%v\n", dict)
-		// we can go faster by writing directly
-		var buf bytes.Buffer
-		buf.WriteString(dict.String() + "\n")
-		j.out.Write(buf.Bytes())
+		j.runHooks(j.level, dict)
+
+		encoder, out := j.snapshotWriter()
+		encoded, err := encoder.Encode(dict)
+		if err != nil {
+			return
+		}
+		out.Write(encoded)
 	})
 }
 
-// Pretty-print the log statement (only once per request).
-// NOTE: This function is for debugging only. For production, use Print() instead.
+// Pretty-print the log statement (only once per request), indented
+// two spaces per level for human reading. Unlike Print, the output
+// isn't newline-delimited single-line JSON, so prefer Print for
+// machine-consumed logs.
 func (j *JLog) PrettyPrint() {
 	j.once.Do(func() {
 		ts := time.Time.Format(time.Now(), j.timeLayout)
@@ -175,17 +268,8 @@ func (j *JLog) PrettyPrint() {
 			dict.Set(elem)
 		}
 
-		dir, _ := os.MkdirTemp("", "test_*")
-		_ = os.WriteFile(dir+"/test.json", []byte(dict.String()), 0660)
-
-		cmdStr := "cd %v && cat test.json | jq"
-		cmd := fmt.Sprintf(cmdStr, dir)
-		e := exec.Command("/bin/bash", "-c", cmd)
-		e.Stdout = os.Stdout
-
-		if err := e.Run(); err != nil {
-			log.Fatalf("error: %v", err)
-		}
+		j.out.Write(dict.MarshalIndent("", "  "))
+		j.out.Write([]byte("\n"))
 	})
 }
 
@@ -194,6 +278,7 @@ func (j *JLog) Info(format string, args ...string) {
 	j.mu.Lock()
 	defer j.mu.Unlock()
 
+	j.cowMsgs()
 	if len(args) > 0 {
 		j.msgs.AddSafe(format, args...)
 	} else {
@@ -210,6 +295,7 @@ func (j *JLog) Warn(format string, args ...string) {
 		j.level = WARN
 	}
 
+	j.cowMsgs()
 	if len(args) > 0 {
 		j.msgs.AddSafe(format, args...)
 	} else {
@@ -223,8 +309,26 @@ func (j *JLog) Error(err error) {
 	defer j.mu.Unlock()
 
 	j.level = ERROR
-	pair := JObject(JPair{Key: ERR_KEY, Val: MakeSafe(err.Error())})
+	j.cowObjects()
+	pair := JObject(JPair{Key: ERR_KEY, Val: err.Error()})
+	j.objects[pair.GetKey()] = pair
+
+	if j.enableStack {
+		j.attachStack(captureStack())
+	}
+}
+
+// ErrorStack behaves like Error but always captures and attaches a
+// stack trace under STACK_KEY, regardless of SetStack.
+func (j *JLog) ErrorStack(err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.level = ERROR
+	j.cowObjects()
+	pair := JObject(JPair{Key: ERR_KEY, Val: err.Error()})
 	j.objects[pair.GetKey()] = pair
+	j.attachStack(captureStack())
 }
 
 // LogFunc returns level, messages, objects
@@ -237,8 +341,15 @@ func (j *JLog) Log(f LogFunc, err error, args ...string) {
 
 	level, msgs, objects := f(err, args...)
 	j.level = level
-	j.msgs.Vals = append(j.msgs.Vals, msgs...)
 
+	if len(msgs) > 0 {
+		j.cowMsgs()
+		j.msgs.Vals = append(j.msgs.Vals, msgs...)
+	}
+
+	if len(objects) > 0 {
+		j.cowObjects()
+	}
 	for _, obj := range objects {
 		j.objects[obj.GetKey()] = obj
 	}
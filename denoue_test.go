@@ -1,8 +1,15 @@
 package denoue
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"math"
+	"runtime"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -78,6 +85,494 @@ func Test_NoPrint(t *testing.T) {
 	jlog.Print()
 }
 
+func Test_Event(t *testing.T) {
+	var buf bytes.Buffer
+	jlog := New()
+	jlog.SetOutput(&buf)
+
+	jlog.InfoEvent().
+		Str("url", "/ping").
+		Int("status", 200).
+		Bool("cached", true).
+		Dict("request", func(e *Event) {
+			e.Str("method", "GET")
+		}).
+		Msg("done")
+
+	out := buf.String()
+	for _, want := range []string{
+		`"level": "INFO"`,
+		`"url": "/ping"`,
+		`"status": 200`,
+		`"cached": true`,
+		`"request": {"method": "GET"}`,
+		`"msg": "done"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output %q does not contain %q", out, want)
+		}
+	}
+}
+
+// Test_Event_encoderAndHooks guards against Event.send() bypassing the
+// same write path as Print: SetEncoder and AddHook must apply to
+// Event-based logging exactly as they do to the map-based API.
+func Test_Event_encoderAndHooks(t *testing.T) {
+	var buf bytes.Buffer
+	jlog := New()
+	jlog.SetOutput(&buf)
+	jlog.SetEncoder(NewCBOREncoder())
+
+	hook := &recordingHook{}
+	jlog.AddHook(hook)
+
+	jlog.InfoEvent().Str("url", "/ping").Msg("done")
+
+	if len(hook.levels) != 1 || hook.levels[0] != INFO {
+		t.Fatalf("expected hook to observe one INFO event, got %v", hook.levels)
+	}
+	if buf.Len() == 0 {
+		t.Fatal("expected CBOR-encoded output, got nothing")
+	}
+	if strings.Contains(buf.String(), `"url": "/ping"`) {
+		t.Errorf("expected CBOR output, got what looks like JSON text: %q", buf.String())
+	}
+}
+
+// Test_Event_dictWithNoFields guards against a nested Dict that ends up
+// empty (e.g. a conditional branch that adds no fields) corrupting the
+// record once a hook or non-default encoder forces the toDict/JDict.String
+// path; see TestJDict_String_empty for the underlying bug.
+func Test_Event_dictWithNoFields(t *testing.T) {
+	var buf bytes.Buffer
+	jlog := New()
+	jlog.SetOutput(&buf)
+	jlog.AddHook(&recordingHook{})
+
+	jlog.InfoEvent().
+		Dict("request", func(e *Event) {}).
+		Msg("done")
+
+	out := buf.String()
+	if !strings.Contains(out, `"request": {}`) {
+		t.Errorf("output %q does not contain %q", out, `"request": {}`)
+	}
+}
+
+func Test_WithContext(t *testing.T) {
+	parent := New()
+	parent.SetPair("request_id", "abc123")
+
+	child := parent.With()
+	child.SetPair("caller", "handler.go:10")
+
+	ctx := child.WithContext(context.Background())
+	got := FromContext(ctx)
+
+	if got != child {
+		t.Fatalf("FromContext returned %p, want %p", got, child)
+	}
+	if _, err := parent.Get("caller"); err == nil {
+		t.Error("child's field leaked into parent")
+	}
+	if _, err := child.Get("request_id"); err != nil {
+		t.Error("child did not inherit parent's field")
+	}
+}
+
+func Test_FromContext_empty(t *testing.T) {
+	if FromContext(context.Background()) == nil {
+		t.Fatal("FromContext should never return nil")
+	}
+}
+
+// Test_With_msgsCOW guards against the parent and a With() child
+// sharing msgs.Vals' backing array: give the parent spare capacity,
+// then interleave an Info on each and make sure neither overwrites the
+// other's message before Print.
+func Test_With_msgsCOW(t *testing.T) {
+	parent := New()
+	parent.Info("one")
+	parent.Info("two")
+	parent.Info("three") // leaves spare capacity in msgs.Vals
+
+	child := parent.With()
+	child.Info("child message")
+	parent.Info("parent message")
+
+	childMsgs := child.msgs.Vals
+	if childMsgs[len(childMsgs)-1] != "child message" {
+		t.Fatalf("child's own message was overwritten: got %v", childMsgs)
+	}
+
+	parentMsgs := parent.msgs.Vals
+	if parentMsgs[len(parentMsgs)-1] != "parent message" {
+		t.Fatalf("parent's message missing: got %v", parentMsgs)
+	}
+}
+
+// Test_With_hooksCOW guards against the parent and a With() child
+// sharing hooks' backing array: give the parent spare capacity, then
+// add a hook on each and make sure neither overwrites the other's hook.
+func Test_With_hooksCOW(t *testing.T) {
+	parent := New()
+	parent.AddHook(&recordingHook{})
+	parent.AddHook(&recordingHook{})
+	parent.AddHook(&recordingHook{}) // leaves spare capacity in hooks
+
+	child := parent.With()
+	childHook := &recordingHook{}
+	child.AddHook(childHook)
+	parent.AddHook(&recordingHook{})
+
+	if got := child.hooks[len(child.hooks)-1]; got != Hook(childHook) {
+		t.Fatalf("child's own hook was overwritten: got %v", got)
+	}
+	if len(parent.hooks) != 4 {
+		t.Fatalf("expected parent to have 4 hooks, got %d", len(parent.hooks))
+	}
+	if len(child.hooks) != 4 {
+		t.Fatalf("expected child to have 4 hooks, got %d", len(child.hooks))
+	}
+}
+
+func Test_ErrorStack(t *testing.T) {
+	jlog := New()
+	jlog.ErrorStack(errors.New("boom"))
+
+	stack, err := Get[JArray](jlog, STACK_KEY)
+	if err != nil {
+		t.Fatalf("expected a stack trace under %q, got error: %v", STACK_KEY, err)
+	}
+	if len(stack.Vals) == 0 {
+		t.Fatal("expected at least one captured frame")
+	}
+	for _, frame := range stack.Vals {
+		for _, internal := range []string{"captureStack", "attachStack", "ErrorStack", "runtime.Callers"} {
+			if strings.Contains(frame, internal) {
+				t.Errorf("expected denoue's own frames to be filtered out, got frame containing %q: %q", internal, frame)
+			}
+		}
+	}
+}
+
+func Test_Error_noStackByDefault(t *testing.T) {
+	jlog := New()
+	jlog.Error(errors.New("boom"))
+
+	if _, err := jlog.Get(STACK_KEY); err == nil {
+		t.Error("expected no stack trace when SetStack hasn't been called")
+	}
+}
+
+func Test_Error_stackEnabled(t *testing.T) {
+	jlog := New()
+	jlog.SetStack(true)
+	jlog.Error(errors.New("boom"))
+
+	if _, err := jlog.Get(STACK_KEY); err != nil {
+		t.Errorf("expected a stack trace after SetStack(true), got error: %v", err)
+	}
+}
+
+// Test_SetStackMarshaler guards against SetStackMarshaler being wired up
+// but never invoked: it installs a custom marshaler and checks both that
+// it ran instead of defaultStackMarshaler, and that ErrorStack passed it
+// the same denoue-filtered frames.
+func Test_SetStackMarshaler(t *testing.T) {
+	jlog := New()
+
+	var gotFrames []runtime.Frame
+	jlog.SetStackMarshaler(func(frames []runtime.Frame) JObject {
+		gotFrames = frames
+		return NewJInt(STACK_KEY, int64(len(frames)))
+	})
+
+	jlog.ErrorStack(errors.New("boom"))
+
+	stack, err := Get[JIntPair](jlog, STACK_KEY)
+	if err != nil {
+		t.Fatalf("expected custom marshaler's JIntPair under %q, got error: %v", STACK_KEY, err)
+	}
+	if stack.Val != int64(len(gotFrames)) {
+		t.Errorf("stack count = %d, want %d frames the marshaler received", stack.Val, len(gotFrames))
+	}
+	if len(gotFrames) == 0 {
+		t.Fatal("expected the custom marshaler to receive at least one frame")
+	}
+	for _, f := range gotFrames {
+		if strings.Contains(f.Function, "captureStack") || strings.Contains(f.Function, "attachStack") {
+			t.Errorf("expected denoue's own frames filtered before reaching the custom marshaler, got %q", f.Function)
+		}
+	}
+}
+
+type recordingHook struct {
+	levels []string
+}
+
+func (h *recordingHook) Run(level string, dict JDict) error {
+	h.levels = append(h.levels, level)
+	dict.SetPair("mutated", "yes") // must not affect the record JLog writes
+	return nil
+}
+
+func Test_AddHook(t *testing.T) {
+	var buf bytes.Buffer
+	jlog := New()
+	jlog.SetOutput(&buf)
+
+	hook := &recordingHook{}
+	jlog.AddHook(hook)
+
+	jlog.Info("hello")
+	jlog.Print()
+
+	if len(hook.levels) != 1 || hook.levels[0] != INFO {
+		t.Fatalf("expected hook to observe one INFO record, got %v", hook.levels)
+	}
+	if strings.Contains(buf.String(), "mutated") {
+		t.Errorf("hook mutation leaked into output: %s", buf.String())
+	}
+}
+
+// Test_AddHook_isolatedBetweenHooks guards against runHooks sharing one
+// dict.clone() across the whole hook chain: a hook that mutates its dict
+// must not leak that mutation into the next hook's view of the record.
+func Test_AddHook_isolatedBetweenHooks(t *testing.T) {
+	jlog := New()
+	jlog.SetOutput(&bytes.Buffer{})
+
+	jlog.AddHook(hookFunc(func(level string, dict JDict) error {
+		dict.SetPair("injected", "leaked")
+		return nil
+	}))
+
+	var sawInjected bool
+	jlog.AddHook(hookFunc(func(level string, dict JDict) error {
+		_, sawInjected = dict.objects["injected"]
+		return nil
+	}))
+
+	jlog.Info("hello")
+	jlog.Print()
+
+	if sawInjected {
+		t.Error("first hook's mutation leaked into the second hook's dict")
+	}
+}
+
+func Test_AddHook_errorReported(t *testing.T) {
+	jlog := New()
+	jlog.SetOutput(&bytes.Buffer{})
+
+	boom := errors.New("ship failed")
+	jlog.AddHook(hookFunc(func(level string, dict JDict) error { return boom }))
+
+	var reported error
+	jlog.SetOnHookError(func(err error) { reported = err })
+
+	jlog.Info("hello")
+	jlog.Print()
+
+	if reported != boom {
+		t.Errorf("expected OnHookError to report %v, got %v", boom, reported)
+	}
+}
+
+type hookFunc func(level string, dict JDict) error
+
+func (f hookFunc) Run(level string, dict JDict) error { return f(level, dict) }
+
+// Test_AddHook_concurrentWithPrint guards against races between AddHook
+// (which mutates j.hooks under j.mu) and Print (which reads it via
+// runHooks); run with -race.
+func Test_AddHook_concurrentWithPrint(t *testing.T) {
+	jlog := New()
+	jlog.SetOutput(&bytes.Buffer{})
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			jlog.AddHook(&recordingHook{})
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			jlog.Reset()
+			jlog.Info("hello")
+			jlog.Print()
+		}
+	}()
+
+	wg.Wait()
+}
+
+// Test_SetEncoder_concurrentWithPrint guards against races between
+// SetEncoder/SetOutput (which mutate j.encoder/j.out under j.mu) and
+// Print/Event.send (which now read them via snapshotWriter); run with
+// -race. Output goes to io.Discard, which is safe for concurrent
+// writes, so any race reported here is in the encoder/out fields
+// themselves, not in a shared io.Writer.
+func Test_SetEncoder_concurrentWithPrint(t *testing.T) {
+	jlog := New()
+	jlog.SetOutput(io.Discard)
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			jlog.SetEncoder(jsonEncoder{})
+			jlog.SetOutput(io.Discard)
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			jlog.Reset()
+			jlog.Info("hello")
+			jlog.Print()
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			jlog.InfoEvent().Str("x", "y").Msg("done")
+		}
+	}()
+
+	wg.Wait()
+}
+
+func ExampleJDict_String_typed() {
+	d := NewJDict()
+	d.Set(NewJInt("status", 200))
+	d.Set(NewJFloat("latency_ms", 12.5))
+	d.Set(NewJBool("cached", true))
+	fmt.Println(d)
+
+	// Output:
+	// {"cached": true, "latency_ms": 12.5, "status": 200}
+}
+
+func TestJArray_typedVals(t *testing.T) {
+	a := NewJArray("array")
+	a.Add("hello")
+	a.AddInt(1)
+	a.AddFloat(2.5)
+	a.AddBool(true)
+
+	got := a.String()
+	want := `"array": ["hello", 1, 2.5, true]`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+// TestJFloatPair_nonFinite guards against NewJFloat/AddFloat producing
+// invalid JSON tokens like NaN or +Inf; non-finite values render as null,
+// matching encoding/json's treatment of non-finite floats.
+func TestJFloatPair_nonFinite(t *testing.T) {
+	for _, v := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		pair := NewJFloat("x", v)
+		if got, want := pair.String(), `"x": null`; got != want {
+			t.Errorf("JFloatPair.String() with %v = %q, want %q", v, got, want)
+		}
+	}
+
+	a := NewJArray("array")
+	a.AddFloat(math.NaN())
+	a.AddFloat(math.Inf(1))
+	if got, want := a.String(), `"array": [null, null]`; got != want {
+		t.Errorf("JArray.String() with non-finite floats = %q, want %q", got, want)
+	}
+}
+
+// TestJDict_String_empty guards against a zero-key JDict panicking in
+// String (out[:len(out)-2] on an empty out), reachable via any empty
+// JGroup, e.g. jlog.Set(JGroup{Key: "request", Dict: NewJDict()}).
+func TestJDict_String_empty(t *testing.T) {
+	d := NewJDict()
+	if got, want := d.String(), OC+CC; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	group := NewJGroup("request", NewJDict())
+	if got, want := group.String(), `"request": {}`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+// TestJPair_String_escapesAtRenderTime guards against regressing escaping
+// back to construction time: JPair.Val/JArray.Vals/ByteVals hold the
+// caller's raw value (see denoue/cbor's Test_Encode_doesNotDoubleEscapeQuotes),
+// so JSON text rendering must still escape embedded quotes itself.
+func TestJPair_String_escapesAtRenderTime(t *testing.T) {
+	pair := NewJPair("error", `say "hi"`)
+	if got, want := pair.String(), `"error": "say \"hi\""`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	arr := NewJArray("arr")
+	arr.Add(`say "hi"`)
+	arr.AddSafe(`also "hi"`)
+	if got, want := arr.String(), `"arr": ["say \"hi\"", "also \"hi\""]`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestJDict_MarshalIndent(t *testing.T) {
+	d := NewJDict()
+	d.SetPair("cat", "meow")
+	d.SetPair("dog", "woof")
+
+	got := string(d.MarshalIndent("", "  "))
+	want := "{\n  \"cat\": \"meow\",\n  \"dog\": \"woof\"\n}"
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func BenchmarkJLog_InfoEventAndMsg(b *testing.B) {
+	jlog := New()
+	jlog.SetOutput(bytes.NewBuffer(nil))
+	for i := 0; i < b.N; i++ {
+		jlog.InfoEvent().Str("url", "/ping").Int("status", 200).Msg("done")
+	}
+}
+
+// Test_Event_allocsBelowMapBasedAPI guards against Event regressing back
+// to the map-allocating design it was meant to replace: for the default
+// encoder with no hooks, composing and sending an event should cost far
+// fewer allocations than the map-based Info/Print path.
+func Test_Event_allocsBelowMapBasedAPI(t *testing.T) {
+	jlog := New()
+	jlog.SetOutput(bytes.NewBuffer(nil))
+
+	eventAllocs := testing.AllocsPerRun(100, func() {
+		jlog.InfoEvent().Str("url", "/ping").Int("status", 200).Msg("done")
+	})
+
+	mapAllocs := testing.AllocsPerRun(100, func() {
+		jlog.Reset()
+		jlog.Info("hello")
+		jlog.Print()
+	})
+
+	if eventAllocs >= mapAllocs {
+		t.Errorf("Event allocs/op (%v) did not drop below map-based Info/Print allocs/op (%v)", eventAllocs, mapAllocs)
+	}
+}
+
 func ExampleJArray_String() {
 	a := NewJArray("array")
 	a.Add("hello")
@@ -0,0 +1,157 @@
+package denoue
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// Encoder renders a composed JDict into the bytes that get written to
+// JLog's output. The default is jsonEncoder, matching JDict.String();
+// see the denoue/cbor subpackage for a binary alternative.
+type Encoder interface {
+	Encode(d JDict) ([]byte, error)
+}
+
+// jsonEncoder is the Encoder used by New(), preserving the historical
+// JDict.String() output.
+type jsonEncoder struct{}
+
+func (jsonEncoder) Encode(d JDict) ([]byte, error) {
+	return []byte(d.String() + "\n"), nil
+}
+
+// CBOREncoder renders a JDict as an RFC 8949 CBOR map: JDict becomes a
+// map (major 5), keys and JPair/JArray string values become text strings
+// (major 3), JArray becomes an array (major 4), and JArray.ByteVals
+// become byte strings (major 2). Default key ordering (time, level,
+// error) is preserved via JDict.orderedKeys.
+type CBOREncoder struct{}
+
+// NewCBOREncoder returns an Encoder that writes CBOR instead of JSON
+// text, for use with JLog.SetEncoder.
+func NewCBOREncoder() *CBOREncoder {
+	return &CBOREncoder{}
+}
+
+func (CBOREncoder) Encode(d JDict) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := cborWriteDict(&buf, d); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// cborWriteHead writes a CBOR major type and length/argument, choosing
+// the shortest encoding as required by RFC 8949.
+func cborWriteHead(buf *bytes.Buffer, major byte, n uint64) {
+	switch {
+	case n < 24:
+		buf.WriteByte(major<<5 | byte(n))
+	case n <= 0xff:
+		buf.WriteByte(major<<5 | 24)
+		buf.WriteByte(byte(n))
+	case n <= 0xffff:
+		buf.WriteByte(major<<5 | 25)
+		binary.Write(buf, binary.BigEndian, uint16(n))
+	case n <= 0xffffffff:
+		buf.WriteByte(major<<5 | 26)
+		binary.Write(buf, binary.BigEndian, uint32(n))
+	default:
+		buf.WriteByte(major<<5 | 27)
+		binary.Write(buf, binary.BigEndian, n)
+	}
+}
+
+func cborWriteText(buf *bytes.Buffer, s string) {
+	cborWriteHead(buf, 3, uint64(len(s)))
+	buf.WriteString(s)
+}
+
+func cborWriteBytes(buf *bytes.Buffer, b []byte) {
+	cborWriteHead(buf, 2, uint64(len(b)))
+	buf.Write(b)
+}
+
+// cborWriteInt writes v as a CBOR integer: major 0 (unsigned) for v >= 0,
+// major 1 (negative) otherwise.
+func cborWriteInt(buf *bytes.Buffer, v int64) {
+	if v >= 0 {
+		cborWriteHead(buf, 0, uint64(v))
+		return
+	}
+	cborWriteHead(buf, 1, uint64(-(v + 1)))
+}
+
+// cborWriteFloat writes v as a CBOR double-precision float (major 7,
+// additional info 27).
+func cborWriteFloat(buf *bytes.Buffer, v float64) {
+	buf.WriteByte(7<<5 | 27)
+	binary.Write(buf, binary.BigEndian, math.Float64bits(v))
+}
+
+// cborWriteBool writes v as a CBOR simple value (major 7, true=21, false=20).
+func cborWriteBool(buf *bytes.Buffer, v bool) {
+	if v {
+		buf.WriteByte(7<<5 | 21)
+		return
+	}
+	buf.WriteByte(7<<5 | 20)
+}
+
+func cborWriteDict(buf *bytes.Buffer, d JDict) error {
+	keys := d.orderedKeys()
+	cborWriteHead(buf, 5, uint64(len(keys)))
+	for _, k := range keys {
+		cborWriteText(buf, k)
+		if err := cborWriteObject(buf, d.objects[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func cborWriteArray(buf *bytes.Buffer, a JArray) {
+	total := len(a.Vals) + len(a.ByteVals) + len(a.IntVals) + len(a.FloatVals) + len(a.BoolVals)
+	cborWriteHead(buf, 4, uint64(total))
+	for _, v := range a.Vals {
+		cborWriteText(buf, v)
+	}
+	for _, b := range a.ByteVals {
+		cborWriteBytes(buf, b)
+	}
+	for _, v := range a.IntVals {
+		cborWriteInt(buf, v)
+	}
+	for _, v := range a.FloatVals {
+		cborWriteFloat(buf, v)
+	}
+	for _, v := range a.BoolVals {
+		cborWriteBool(buf, v)
+	}
+}
+
+func cborWriteObject(buf *bytes.Buffer, obj JObject) error {
+	switch v := obj.(type) {
+	case JPair:
+		cborWriteText(buf, v.Val)
+	case JIntPair:
+		cborWriteInt(buf, v.Val)
+	case JFloatPair:
+		cborWriteFloat(buf, v.Val)
+	case JBoolPair:
+		cborWriteBool(buf, v.Val)
+	case JTimePair:
+		cborWriteText(buf, v.Val.Format(v.Layout))
+	case JRawPair:
+		cborWriteBytes(buf, v.Raw)
+	case JGroup:
+		return cborWriteDict(buf, v.Dict)
+	case JArray:
+		cborWriteArray(buf, v)
+	default:
+		return fmt.Errorf("cbor: unsupported JObject type %T", obj)
+	}
+	return nil
+}
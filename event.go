@@ -0,0 +1,272 @@
+package denoue
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// eventBufPool recycles the []byte buffers backing Event so a fluent
+// chain (Str/Int/.../Msg) reuses a buffer instead of allocating a new
+// one per event. newEvent still allocates the Event itself and its
+// formatted timestamp.
+var eventBufPool = sync.Pool{
+	New: func() any { b := make([]byte, 0, 256); return &b },
+}
+
+// eventFieldsPool recycles the []eventField slices Event uses to
+// rebuild a JDict on demand, so that cost is only ever paid when a hook
+// or a non-default encoder is actually configured.
+var eventFieldsPool = sync.Pool{
+	New: func() any { f := make([]eventField, 0, 8); return &f },
+}
+
+type eventFieldKind byte
+
+const (
+	eventStr eventFieldKind = iota
+	eventInt
+	eventBool
+	eventArray
+	eventGroup
+)
+
+// eventField records one field of an Event without boxing its value in
+// a JObject, so recording it costs a slice append rather than a map
+// insert plus an interface allocation. Only read back by Event.toDict,
+// on the path where a hook or non-default encoder needs a real JDict.
+type eventField struct {
+	key   string
+	kind  eventFieldKind
+	str   string
+	i64   int64
+	b     bool
+	arr   []string
+	group []eventField
+}
+
+// Event is a fluent, low-allocation builder for a single log record,
+// modeled on zerolog:
+//
+//	jlog.InfoEvent().Str("url", "/ping").Int("status", 200).Msg("done")
+//
+// Str/Int/Bool/Array/Dict append directly into a pooled []byte buffer as
+// JSON text, with no intermediate map. When j uses the default encoder
+// and has no hooks, Msg/Send write that buffer straight to j's output.
+// Otherwise send replays the event's fields into a JDict and runs it
+// through j.runHooks/j.encoder, so SetEncoder and AddHook still apply
+// uniformly to Event-based logging, just without charging the common
+// case for it.
+//
+// The map-based JLog API (Print/Info/Warn/Error) deliberately isn't
+// rewritten to lower onto Event: it predates Event, has its own
+// established SetPair/Set/Get/Pop surface for mutating fields by key
+// after the fact (With, Log's custom JObjects, Pop), and that surface
+// doesn't map cleanly onto Event's append-only buffer. Keeping both
+// means the two pipelines have to agree on behavior by hand — Event
+// initially bypassed Print's encoder/hook path and had to be fixed to
+// route through runHooks/snapshotWriter the same way — so any change to
+// runHooks, snapshotWriter, or JDict.String (the shared serialization
+// logic both paths fall back on) should be exercised against Print and
+// Event alike, not just one.
+type Event struct {
+	j       *JLog
+	level   string
+	buf     *[]byte
+	fields  *[]eventField
+	nFields int
+	done    bool
+}
+
+func newEvent(j *JLog, level string) *Event {
+	bp := eventBufPool.Get().(*[]byte)
+	*bp = (*bp)[:0]
+	fp := eventFieldsPool.Get().(*[]eventField)
+	*fp = (*fp)[:0]
+
+	e := &Event{j: j, level: level, buf: bp, fields: fp}
+	*e.buf = append(*e.buf, '{')
+	ts := time.Now().Format(j.timeLayout)
+	e.appendKey(TIME_KEY)
+	e.appendQuoted(ts)
+	e.appendKey(LEVEL_KEY)
+	e.appendQuoted(level)
+	*e.fields = append(*e.fields, eventField{key: TIME_KEY, kind: eventStr, str: ts})
+	*e.fields = append(*e.fields, eventField{key: LEVEL_KEY, kind: eventStr, str: level})
+	return e
+}
+
+// appendKey writes the (possibly comma-separated) quoted key and colon
+// for the next field.
+func (e *Event) appendKey(key string) {
+	if e.nFields > 0 {
+		*e.buf = append(*e.buf, ',', ' ')
+	}
+	e.nFields++
+	*e.buf = append(*e.buf, '"')
+	*e.buf = append(*e.buf, key...)
+	*e.buf = append(*e.buf, '"', ':', ' ')
+}
+
+func (e *Event) appendQuoted(s string) {
+	*e.buf = append(*e.buf, '"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' {
+			*e.buf = append(*e.buf, '\\')
+		}
+		*e.buf = append(*e.buf, s[i])
+	}
+	*e.buf = append(*e.buf, '"')
+}
+
+// Str adds a string field.
+func (e *Event) Str(key, val string) *Event {
+	e.appendKey(key)
+	e.appendQuoted(val)
+	*e.fields = append(*e.fields, eventField{key: key, kind: eventStr, str: val})
+	return e
+}
+
+// Int adds an integer field, unquoted.
+func (e *Event) Int(key string, val int) *Event {
+	e.appendKey(key)
+	*e.buf = strconv.AppendInt(*e.buf, int64(val), 10)
+	*e.fields = append(*e.fields, eventField{key: key, kind: eventInt, i64: int64(val)})
+	return e
+}
+
+// Bool adds a boolean field, unquoted.
+func (e *Event) Bool(key string, val bool) *Event {
+	e.appendKey(key)
+	*e.buf = strconv.AppendBool(*e.buf, val)
+	*e.fields = append(*e.fields, eventField{key: key, kind: eventBool, b: val})
+	return e
+}
+
+// Array adds a string array field.
+func (e *Event) Array(key string, vals ...string) *Event {
+	e.appendKey(key)
+	*e.buf = append(*e.buf, '[')
+	for i, v := range vals {
+		if i > 0 {
+			*e.buf = append(*e.buf, ',', ' ')
+		}
+		e.appendQuoted(v)
+	}
+	*e.buf = append(*e.buf, ']')
+	*e.fields = append(*e.fields, eventField{key: key, kind: eventArray, arr: vals})
+	return e
+}
+
+// Dict adds a nested object field, built by fn on a child Event that
+// shares this Event's buffer.
+func (e *Event) Dict(key string, fn func(*Event)) *Event {
+	e.appendKey(key)
+	*e.buf = append(*e.buf, '{')
+	var childFields []eventField
+	child := &Event{j: e.j, buf: e.buf, fields: &childFields}
+	fn(child)
+	*e.buf = append(*e.buf, '}')
+	*e.fields = append(*e.fields, eventField{key: key, kind: eventGroup, group: childFields})
+	return e
+}
+
+// Msg sets the message field and flushes the event to the logger's
+// output.
+func (e *Event) Msg(msg string) {
+	if e.done {
+		return
+	}
+	e.Str(EVENT_MSG_KEY, msg)
+	e.send()
+}
+
+// Send flushes the event to the logger's output without a message.
+func (e *Event) Send() {
+	if e.done {
+		return
+	}
+	e.send()
+}
+
+// toDict replays the event's recorded fields into a real JDict, for the
+// path where a hook or non-default encoder needs one.
+func (e *Event) toDict() JDict {
+	dict := NewJDict()
+	buildEventDict(&dict, *e.fields)
+	return dict
+}
+
+func buildEventDict(dict *JDict, fields []eventField) {
+	for _, f := range fields {
+		switch f.kind {
+		case eventStr:
+			dict.Set(JPair{Key: f.key, Val: f.str})
+		case eventInt:
+			dict.Set(NewJInt(f.key, f.i64))
+		case eventBool:
+			dict.Set(JBoolPair{Key: f.key, Val: f.b})
+		case eventArray:
+			arr := NewJArray(f.key)
+			for _, v := range f.arr {
+				arr.Add(v)
+			}
+			dict.Set(arr)
+		case eventGroup:
+			child := NewJDict()
+			buildEventDict(&child, f.group)
+			dict.Set(JGroup{Key: f.key, Dict: child})
+		}
+	}
+}
+
+// send composes and writes the event. When j uses the default encoder
+// and has no hooks, it writes the pooled buffer straight to j's output;
+// otherwise it falls back to building a JDict so j.runHooks/j.encoder
+// still see the record, just without paying the map-allocation cost on
+// the common path. Like Print, it reads encoder/out via snapshotWriter
+// so it can't race a concurrent SetEncoder/SetOutput.
+func (e *Event) send() {
+	e.done = true
+	*e.buf = append(*e.buf, '}', '\n')
+
+	encoder, out := e.j.snapshotWriter()
+	if _, isDefault := encoder.(jsonEncoder); isDefault && !e.j.hasHooks() {
+		out.Write(*e.buf)
+		e.release()
+		return
+	}
+
+	dict := e.toDict()
+	e.j.runHooks(e.level, dict)
+	encoded, err := encoder.Encode(dict)
+	if err == nil {
+		out.Write(encoded)
+	}
+	e.release()
+}
+
+func (e *Event) release() {
+	*e.buf = (*e.buf)[:0]
+	eventBufPool.Put(e.buf)
+	*e.fields = (*e.fields)[:0]
+	eventFieldsPool.Put(e.fields)
+}
+
+// InfoEvent starts a fluent INFO-level event.
+func (j *JLog) InfoEvent() *Event {
+	return newEvent(j, INFO)
+}
+
+// WarnEvent starts a fluent WARN-level event.
+func (j *JLog) WarnEvent() *Event {
+	return newEvent(j, WARN)
+}
+
+// ErrorEvent starts a fluent ERROR-level event with the error pre-set
+// under ERR_KEY.
+func (j *JLog) ErrorEvent(err error) *Event {
+	e := newEvent(j, ERROR)
+	e.Str(ERR_KEY, err.Error())
+	return e
+}
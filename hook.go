@@ -0,0 +1,78 @@
+package denoue
+
+// Hook lets callers observe every composed log record, e.g. to ship it
+// somewhere besides JLog's own output. See denoue/hooks/syslog,
+// denoue/hooks/multi, and denoue/hooks/sampling for built-in hooks.
+type Hook interface {
+	Run(level string, dict JDict) error
+}
+
+// AddHook registers a hook to run after Print composes the final
+// record but before it's written to the output. Hooks receive a cloned
+// snapshot of the record, not JLog's internal fields map, so mutating
+// it can't affect what Print writes. Hook errors are reported to
+// OnHookError (see SetOnHookError) rather than panicking.
+func (j *JLog) AddHook(h Hook) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.cowHooks()
+	j.hooks = append(j.hooks, h)
+}
+
+// SetOnHookError sets the callback used to report errors returned by
+// hooks. If unset, hook errors are silently dropped.
+func (j *JLog) SetOnHookError(f func(error)) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.onHookError = f
+}
+
+// cowHooks clones j.hooks if its backing array may still be aliased
+// with another logger (see With), so that registering a hook on one
+// logger never overwrites or leaks into the other. Callers must hold
+// j.mu.
+func (j *JLog) cowHooks() {
+	if !j.hooksShared {
+		return
+	}
+
+	j.hooks = append([]Hook(nil), j.hooks...)
+	j.hooksShared = false
+}
+
+// hasHooks reports whether any hooks are registered. Event.send uses it
+// to skip building a JDict for runHooks when there's nothing to run.
+func (j *JLog) hasHooks() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return len(j.hooks) > 0
+}
+
+// runHooks invokes all registered hooks, each with its own clone of
+// dict, reporting the given level rather than j.level so Event-based
+// records (which don't mutate j.level) report their own level. Cloning
+// per hook (rather than once for the whole chain) keeps one hook's
+// mutation from leaking into the next hook's view of the record. It
+// takes j.mu itself (callers must not already hold it) just long enough
+// to snapshot the hooks slice and error callback, so a concurrent
+// AddHook/SetOnHookError can't race with Print, and a hook calling back
+// into j doesn't deadlock.
+func (j *JLog) runHooks(level string, dict JDict) {
+	j.mu.Lock()
+	hooks := j.hooks
+	onHookError := j.onHookError
+	j.mu.Unlock()
+
+	if len(hooks) == 0 {
+		return
+	}
+
+	for _, h := range hooks {
+		if err := h.Run(level, dict.clone()); err != nil && onHookError != nil {
+			onHookError(err)
+		}
+	}
+}
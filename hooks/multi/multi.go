@@ -0,0 +1,32 @@
+// Package multi fans a composed log record out to several io.Writers.
+package multi
+
+import (
+	"io"
+
+	"github.com/Eratosthenes/denoue"
+)
+
+// Hook writes each record to every Writer.
+type Hook struct {
+	Writers []io.Writer
+}
+
+// New returns a Hook that fans out to the given writers.
+func New(writers ...io.Writer) *Hook {
+	return &Hook{Writers: writers}
+}
+
+// Run implements denoue.Hook, attempting every writer and returning the
+// first error encountered, if any.
+func (h *Hook) Run(level string, dict denoue.JDict) error {
+	line := []byte(dict.String() + "\n")
+
+	var firstErr error
+	for _, w := range h.Writers {
+		if _, err := w.Write(line); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
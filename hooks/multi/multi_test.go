@@ -0,0 +1,50 @@
+package multi
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/Eratosthenes/denoue"
+)
+
+func Test_Hook_fansOutToEveryWriter(t *testing.T) {
+	var a, b bytes.Buffer
+	h := New(&a, &b)
+
+	dict := denoue.NewJDict()
+	dict.SetPair("msg", "hello")
+
+	if err := h.Run(denoue.INFO, dict); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	want := dict.String() + "\n"
+	if a.String() != want {
+		t.Errorf("writer a got %q, want %q", a.String(), want)
+	}
+	if b.String() != want {
+		t.Errorf("writer b got %q, want %q", b.String(), want)
+	}
+}
+
+type errWriter struct{}
+
+func (errWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func Test_Hook_returnsFirstErrorButStillWritesOthers(t *testing.T) {
+	var ok bytes.Buffer
+	h := New(errWriter{}, &ok)
+
+	dict := denoue.NewJDict()
+	dict.SetPair("msg", "hello")
+	err := h.Run(denoue.INFO, dict)
+	if err == nil {
+		t.Fatal("expected an error from the failing writer")
+	}
+	if ok.Len() == 0 {
+		t.Error("expected the working writer to still receive the record")
+	}
+}
@@ -0,0 +1,45 @@
+// Package sampling wraps another hook and thins out how often it's
+// called, so a service under load can keep 100% of ERROR records but
+// only a fraction of lower levels.
+package sampling
+
+import (
+	"sync"
+
+	"github.com/Eratosthenes/denoue"
+)
+
+// Hook forwards every ERROR record to the wrapped hook, but only 1-in-N
+// of records at other levels, counting separately per level.
+type Hook struct {
+	next denoue.Hook
+	n    int
+
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+// New returns a Hook that forwards to next, keeping all ERROR records
+// and 1-in-n of every other level. n must be > 0; n <= 0 is treated as
+// 1, i.e. every record is kept.
+func New(n int, next denoue.Hook) *Hook {
+	if n <= 0 {
+		n = 1
+	}
+	return &Hook{next: next, n: n, counts: make(map[string]int)}
+}
+
+// Run implements denoue.Hook.
+func (h *Hook) Run(level string, dict denoue.JDict) error {
+	if level != denoue.ERROR {
+		h.mu.Lock()
+		h.counts[level]++
+		keep := h.counts[level]%h.n == 0
+		h.mu.Unlock()
+
+		if !keep {
+			return nil
+		}
+	}
+	return h.next.Run(level, dict)
+}
@@ -0,0 +1,57 @@
+package sampling
+
+import (
+	"testing"
+
+	"github.com/Eratosthenes/denoue"
+)
+
+type countingHook struct {
+	levels []string
+}
+
+func (h *countingHook) Run(level string, dict denoue.JDict) error {
+	h.levels = append(h.levels, level)
+	return nil
+}
+
+func Test_Hook_keepsAllErrors(t *testing.T) {
+	next := &countingHook{}
+	h := New(3, next)
+
+	for i := 0; i < 5; i++ {
+		h.Run(denoue.ERROR, denoue.NewJDict())
+	}
+
+	if len(next.levels) != 5 {
+		t.Errorf("expected all 5 ERROR records forwarded, got %d", len(next.levels))
+	}
+}
+
+func Test_Hook_samples1InN(t *testing.T) {
+	next := &countingHook{}
+	h := New(3, next)
+
+	for i := 0; i < 9; i++ {
+		h.Run(denoue.INFO, denoue.NewJDict())
+	}
+
+	if len(next.levels) != 3 {
+		t.Errorf("expected 1-in-3 of 9 INFO records forwarded (3), got %d", len(next.levels))
+	}
+}
+
+func Test_New_nonPositiveN(t *testing.T) {
+	next := &countingHook{}
+	h := New(0, next)
+
+	for i := 0; i < 3; i++ {
+		if err := h.Run(denoue.WARN, denoue.NewJDict()); err != nil {
+			t.Fatalf("Run returned error: %v", err)
+		}
+	}
+
+	if len(next.levels) != 3 {
+		t.Errorf("expected n<=0 to keep every record, got %d of 3 forwarded", len(next.levels))
+	}
+}
@@ -0,0 +1,37 @@
+// Package syslog ships composed log records to the local syslog
+// daemon, mapping JLog's INFO/WARN/ERROR levels to syslog priorities.
+package syslog
+
+import (
+	"log/syslog"
+
+	"github.com/Eratosthenes/denoue"
+)
+
+// Hook forwards each record's rendered form to syslog.
+type Hook struct {
+	w *syslog.Writer
+}
+
+// New dials the local syslog daemon under tag and returns a denoue.Hook
+// that forwards records to it.
+func New(tag string) (*Hook, error) {
+	w, err := syslog.New(syslog.LOG_INFO, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &Hook{w: w}, nil
+}
+
+// Run implements denoue.Hook.
+func (h *Hook) Run(level string, dict denoue.JDict) error {
+	msg := dict.String()
+	switch level {
+	case denoue.WARN:
+		return h.w.Warning(msg)
+	case denoue.ERROR:
+		return h.w.Err(msg)
+	default:
+		return h.w.Info(msg)
+	}
+}
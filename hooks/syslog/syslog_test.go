@@ -0,0 +1,26 @@
+package syslog
+
+import (
+	"testing"
+
+	"github.com/Eratosthenes/denoue"
+)
+
+// Test_Hook_forwardsByLevel exercises the level-to-priority mapping in
+// Run. It dials the local syslog daemon, so it's skipped in sandboxes
+// without one (e.g. no /dev/log).
+func Test_Hook_forwardsByLevel(t *testing.T) {
+	h, err := New("denoue-test")
+	if err != nil {
+		t.Skipf("no local syslog daemon available: %v", err)
+	}
+
+	dict := denoue.NewJDict()
+	dict.SetPair("msg", "hello")
+
+	for _, level := range []string{denoue.INFO, denoue.WARN, denoue.ERROR} {
+		if err := h.Run(level, dict); err != nil {
+			t.Errorf("Run(%s, ...) returned error: %v", level, err)
+		}
+	}
+}
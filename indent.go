@@ -0,0 +1,127 @@
+package denoue
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// MarshalIndent renders d the same way String does, but with each
+// nested level indented by indent, prefixed by prefix — the shape
+// PrettyPrint uses for human-readable output.
+func (d JDict) MarshalIndent(prefix, indent string) []byte {
+	var buf bytes.Buffer
+	d.writeIndent(&buf, prefix, indent)
+	return buf.Bytes()
+}
+
+func (d JDict) writeIndent(buf *bytes.Buffer, prefix, indent string) {
+	keys := d.orderedKeys()
+	if len(keys) == 0 {
+		buf.WriteString(OC + CC)
+		return
+	}
+
+	buf.WriteString(OC + "\n")
+	childPrefix := prefix + indent
+	for i, k := range keys {
+		buf.WriteString(childPrefix)
+		writeObjectIndent(buf, d.objects[k], childPrefix, indent)
+		if i < len(keys)-1 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString(prefix + CC)
+}
+
+// writeObjectIndent dispatches to the concrete JObject's writeIndent,
+// since the JObject interface itself only requires GetKey and String.
+func writeObjectIndent(buf *bytes.Buffer, obj JObject, prefix, indent string) {
+	switch v := obj.(type) {
+	case JPair:
+		v.writeIndent(buf, prefix, indent)
+	case JArray:
+		v.writeIndent(buf, prefix, indent)
+	case JGroup:
+		v.writeIndent(buf, prefix, indent)
+	default:
+		// JIntPair, JFloatPair, JBoolPair, JTimePair, JRawPair, and any
+		// other JObject never span more than one line, so String() is
+		// already the indented form.
+		buf.WriteString(obj.String())
+	}
+}
+
+// MarshalIndent renders g the same way String does, but with its
+// nested dict indented.
+func (g JGroup) MarshalIndent(prefix, indent string) []byte {
+	var buf bytes.Buffer
+	g.writeIndent(&buf, prefix, indent)
+	return buf.Bytes()
+}
+
+func (g JGroup) writeIndent(buf *bytes.Buffer, prefix, indent string) {
+	buf.WriteString(wrap(g.Key, QM))
+	buf.WriteString(": ")
+	g.Dict.writeIndent(buf, prefix, indent)
+}
+
+// MarshalIndent renders a the same way String does, but with one
+// element per line.
+func (a JArray) MarshalIndent(prefix, indent string) []byte {
+	var buf bytes.Buffer
+	a.writeIndent(&buf, prefix, indent)
+	return buf.Bytes()
+}
+
+func (a JArray) writeIndent(buf *bytes.Buffer, prefix, indent string) {
+	buf.WriteString(wrap(a.Key, QM))
+	buf.WriteString(": ")
+
+	total := len(a.Vals) + len(a.ByteVals) + len(a.IntVals) + len(a.FloatVals) + len(a.BoolVals)
+	if total == 0 {
+		buf.WriteString(OB + CB)
+		return
+	}
+
+	buf.WriteString(OB + "\n")
+	childPrefix := prefix + indent
+	i := 0
+	writeElem := func(s string) {
+		buf.WriteString(childPrefix)
+		buf.WriteString(s)
+		i++
+		if i < total {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n")
+	}
+	for _, v := range a.Vals {
+		writeElem(wrap(MakeSafe(v), QM))
+	}
+	for _, b := range a.ByteVals {
+		writeElem(wrap(MakeSafe(string(b)), QM))
+	}
+	for _, v := range a.IntVals {
+		writeElem(strconv.FormatInt(v, 10))
+	}
+	for _, v := range a.FloatVals {
+		writeElem(formatFloatJSON(v))
+	}
+	for _, v := range a.BoolVals {
+		writeElem(strconv.FormatBool(v))
+	}
+	buf.WriteString(prefix + CB)
+}
+
+// MarshalIndent renders p the same way String does; prefix and indent
+// are unused since a JPair never spans more than one line.
+func (p JPair) MarshalIndent(prefix, indent string) []byte {
+	var buf bytes.Buffer
+	p.writeIndent(&buf, prefix, indent)
+	return buf.Bytes()
+}
+
+func (p JPair) writeIndent(buf *bytes.Buffer, prefix, indent string) {
+	buf.WriteString(p.String())
+}
@@ -0,0 +1,104 @@
+package denoue
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// STACK_KEY is the key under which Error/ErrorStack attach a captured
+// stack trace.
+const STACK_KEY string = "stack"
+
+// StackMarshaler builds the JObject stored under STACK_KEY from the
+// captured frames. Override via SetStackMarshaler to pick a different
+// shape, e.g. a JArray of JGroup with func/file/line pairs.
+type StackMarshaler func(frames []runtime.Frame) JObject
+
+// defaultStackMarshaler renders frames as a JArray, one "pkg.Func\tfile:line"
+// string per frame.
+func defaultStackMarshaler(frames []runtime.Frame) JObject {
+	arr := NewJArray(STACK_KEY)
+	for _, f := range frames {
+		arr.Add(fmt.Sprintf("%s\t%s:%d", f.Function, f.File, f.Line))
+	}
+	return arr
+}
+
+// denouePackagePrefix is this package's function-name prefix (e.g.
+// "github.com/Eratosthenes/denoue."), used to skip denoue's own frames
+// (Error, ErrorStack, captureStack) when walking the stack. Derived from
+// packagePrefixSentinel rather than captureStack itself, since
+// captureStack's body reads denouePackagePrefix and referencing it here
+// would create an initialization cycle.
+var denouePackagePrefix = packagePrefix(packagePrefixSentinel)
+
+// packagePrefixSentinel exists only so denouePackagePrefix has a named,
+// package-local function to derive its prefix from.
+func packagePrefixSentinel() {}
+
+// packagePrefix returns the package-qualified prefix (up to and
+// including the last '.') of fn's name, e.g. calling it with
+// packagePrefixSentinel yields "github.com/Eratosthenes/denoue.".
+func packagePrefix(fn any) string {
+	rf := runtime.FuncForPC(reflect.ValueOf(fn).Pointer())
+	if rf == nil {
+		return ""
+	}
+	name := rf.Name()
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[:i+1]
+	}
+	return name
+}
+
+// captureStack walks the call stack, skipping frames inside denoue
+// itself (Error/ErrorStack/this function), and returns the rest.
+func captureStack() []runtime.Frame {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(1, pcs)
+	callerFrames := runtime.CallersFrames(pcs[:n])
+
+	frames := make([]runtime.Frame, 0, n)
+	for {
+		f, more := callerFrames.Next()
+		if !strings.HasPrefix(f.Function, denouePackagePrefix) {
+			frames = append(frames, f)
+		}
+		if !more {
+			break
+		}
+	}
+	return frames
+}
+
+// SetStack enables or disables stack trace capture on Error, keeping
+// the fast path allocation-free when disabled (the default).
+func (j *JLog) SetStack(enable bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.enableStack = enable
+}
+
+// SetStackMarshaler overrides how captured frames are rendered under
+// STACK_KEY.
+func (j *JLog) SetStackMarshaler(m StackMarshaler) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.stackMarshaler = m
+}
+
+// attachStack marshals frames with j's StackMarshaler (or the default)
+// and stores the result under STACK_KEY. Callers must hold j.mu and
+// have already called cowObjects.
+func (j *JLog) attachStack(frames []runtime.Frame) {
+	marshaler := j.stackMarshaler
+	if marshaler == nil {
+		marshaler = defaultStackMarshaler
+	}
+	obj := marshaler(frames)
+	j.objects[obj.GetKey()] = obj
+}
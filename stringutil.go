@@ -2,7 +2,9 @@ package denoue
 
 import (
 	"fmt"
+	"math"
 	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -23,6 +25,11 @@ const (
 	ERR_KEY   string = "error"
 )
 
+// EVENT_MSG_KEY is the message key used by the Event fluent API (Msg),
+// kept distinct from MSG_KEY since the map-based API stores a list of
+// messages under "msgs" rather than a single "msg" string.
+const EVENT_MSG_KEY string = "msg"
+
 const (
 	OC string = "{"
 	CC string = "}"
@@ -31,6 +38,16 @@ const (
 	QM string = "\""
 )
 
+// formatFloatJSON renders v as a JSON number token, substituting "null"
+// for NaN/±Inf since neither is valid JSON, matching encoding/json's
+// treatment of non-finite floats.
+func formatFloatJSON(v float64) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return "null"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
 func wrap(s string, tokens ...string) string {
 	switch len(tokens) {
 	case 1:
@@ -42,7 +59,9 @@ func wrap(s string, tokens ...string) string {
 	}
 }
 
-func (d JDict) String() string {
+// orderedKeys returns the dict's keys with the default keys (time, level,
+// error) first, in that order, followed by the rest sorted alphabetically.
+func (d JDict) orderedKeys() []string {
 	keys := make([]string, 0, len(d.objects))
 	default_keys := []string{TIME_KEY, LEVEL_KEY, ERR_KEY}
 
@@ -63,6 +82,15 @@ func (d JDict) String() string {
 	sort.Strings(nonDefaultKeys)
 	keys = append(keys, nonDefaultKeys...)
 
+	return keys
+}
+
+func (d JDict) String() string {
+	keys := d.orderedKeys()
+	if len(keys) == 0 {
+		return OC + CC
+	}
+
 	// create the output
 	out := ""
 	for _, k := range keys {
@@ -92,30 +120,33 @@ func (a JArray) String() string {
 	sb.WriteString(OB) // opening bracket
 
 	first := true
-	for _, val := range a.Vals {
+	writeSep := func() {
 		if first {
 			first = false
-			sb.WriteString(wrap(val, QM))
-			continue
+			return
 		}
 		sb.WriteString(", ")
-		sb.WriteString(wrap(val, QM))
 	}
 
-	if len(a.ByteVals) > 0 {
-		for _, b := range a.ByteVals {
-			if first {
-				first = false
-				sb.WriteString(QM)
-				sb.Write(b)
-				sb.WriteString(QM)
-				continue
-			}
-			sb.WriteString(", ")
-			sb.WriteString(QM)
-			sb.Write(b)
-			sb.WriteString(QM)
-		}
+	for _, val := range a.Vals {
+		writeSep()
+		sb.WriteString(wrap(MakeSafe(val), QM))
+	}
+	for _, b := range a.ByteVals {
+		writeSep()
+		sb.WriteString(wrap(MakeSafe(string(b)), QM))
+	}
+	for _, v := range a.IntVals {
+		writeSep()
+		sb.WriteString(strconv.FormatInt(v, 10))
+	}
+	for _, v := range a.FloatVals {
+		writeSep()
+		sb.WriteString(formatFloatJSON(v))
+	}
+	for _, v := range a.BoolVals {
+		writeSep()
+		sb.WriteString(strconv.FormatBool(v))
 	}
 
 	sb.WriteString(CB) // closing bracket
@@ -123,5 +154,5 @@ func (a JArray) String() string {
 }
 
 func (p JPair) String() string {
-	return fmt.Sprintf("%v: %v", wrap(p.Key, QM), wrap(p.Val, QM))
+	return fmt.Sprintf("%v: %v", wrap(p.Key, QM), wrap(MakeSafe(p.Val), QM))
 }
@@ -27,6 +27,16 @@ func (d *JDict) SetPair(key, val string) {
 	d.objects[pair.GetKey()] = pair
 }
 
+// clone returns a JDict backed by a fresh copy of d's map, so a
+// recipient can't affect d by mutating the clone (see JLog.AddHook).
+func (d JDict) clone() JDict {
+	cp := make(map[string]JObject, len(d.objects))
+	for k, v := range d.objects {
+		cp[k] = v
+	}
+	return JDict{objects: cp}
+}
+
 type JGroup struct {
 	Key  string
 	Dict JDict
@@ -41,12 +51,17 @@ func (g JGroup) GetKey() string {
 }
 
 // JArray objects have a key, and a list of values wrapped by square braces.
-// JArray values can only be strings.
+// Values can be strings, byte strings, ints, floats, or bools (added via
+// Add/AddSafe, AddInt, AddFloat, and AddBool respectively); each kind is
+// kept in its own typed slice and rendered in that order.
 // JArrays can only be appended to, not changed.
 type JArray struct {
-	Key      string
-	Vals     []string
-	ByteVals [][]byte
+	Key       string
+	Vals      []string
+	ByteVals  [][]byte
+	IntVals   []int64
+	FloatVals []float64
+	BoolVals  []bool
 }
 
 func NewJArray(key string) JArray {
@@ -57,6 +72,43 @@ func (a JArray) GetKey() string {
 	return a.Key
 }
 
+// AddInt adds an integer to the JArray, rendered without quotes.
+func (a *JArray) AddInt(v int64) {
+	a.IntVals = append(a.IntVals, v)
+}
+
+// AddFloat adds a float to the JArray, rendered without quotes.
+func (a *JArray) AddFloat(v float64) {
+	a.FloatVals = append(a.FloatVals, v)
+}
+
+// AddBool adds a bool to the JArray, rendered without quotes.
+func (a *JArray) AddBool(v bool) {
+	a.BoolVals = append(a.BoolVals, v)
+}
+
+// clone returns a JArray backed by fresh copies of a's slices, so a
+// recipient can't affect a by appending to the clone (see JLog.With).
+func (a JArray) clone() JArray {
+	cp := JArray{Key: a.Key}
+	if a.Vals != nil {
+		cp.Vals = append([]string(nil), a.Vals...)
+	}
+	if a.ByteVals != nil {
+		cp.ByteVals = append([][]byte(nil), a.ByteVals...)
+	}
+	if a.IntVals != nil {
+		cp.IntVals = append([]int64(nil), a.IntVals...)
+	}
+	if a.FloatVals != nil {
+		cp.FloatVals = append([]float64(nil), a.FloatVals...)
+	}
+	if a.BoolVals != nil {
+		cp.BoolVals = append([]bool(nil), a.BoolVals...)
+	}
+	return cp
+}
+
 type escBuf []byte
 
 func (b *escBuf) WriteEscaped(s string) {
@@ -68,18 +120,26 @@ func (b *escBuf) WriteEscaped(s string) {
 	}
 }
 
+// MakeSafe escapes s for embedding inside a JSON string literal (quotes
+// only). It's applied at JSON render time (JPair.String, JArray.String,
+// and their MarshalIndent equivalents), not at construction time: a
+// JObject's Val/Vals/ByteVals hold the caller's raw value, so encoders
+// without JSON's backslash-escaping convention (e.g. denoue/cbor) see
+// the original bytes rather than JSON-escaped ones.
 func MakeSafe(s string) string {
 	buf := make(escBuf, 0, 2*len(s))
 	buf.WriteEscaped(s)
 	return string(buf)
 }
 
-// AddSafe adds a formatted string and arguments to the JArray, escaping quotes.
+// AddSafe adds a formatted string and arguments to the JArray as raw
+// bytes (unescaped); escaping for JSON text output is applied at render
+// time, same as MakeSafe.
 func (a *JArray) AddSafe(format string, args ...string) {
-	buf := make(escBuf, 0, 2*(len(format)+len(args)))
-	buf.WriteEscaped(format)
+	buf := make([]byte, 0, len(format)+len(args))
+	buf = append(buf, format...)
 	for _, arg := range args {
-		buf.WriteEscaped(arg)
+		buf = append(buf, arg...)
 	}
 	a.ByteVals = append(a.ByteVals, buf)
 }
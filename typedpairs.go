@@ -0,0 +1,106 @@
+package denoue
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// JIntPair is a key/integer-value pair, rendered without quotes.
+type JIntPair struct {
+	Key string
+	Val int64
+}
+
+// NewJInt builds a JIntPair.
+func NewJInt(key string, v int64) JIntPair {
+	return JIntPair{Key: key, Val: v}
+}
+
+func (p JIntPair) GetKey() string {
+	return p.Key
+}
+
+func (p JIntPair) String() string {
+	return fmt.Sprintf("%v: %v", wrap(p.Key, QM), strconv.FormatInt(p.Val, 10))
+}
+
+// JFloatPair is a key/float-value pair, rendered without quotes.
+type JFloatPair struct {
+	Key string
+	Val float64
+}
+
+// NewJFloat builds a JFloatPair.
+func NewJFloat(key string, v float64) JFloatPair {
+	return JFloatPair{Key: key, Val: v}
+}
+
+func (p JFloatPair) GetKey() string {
+	return p.Key
+}
+
+func (p JFloatPair) String() string {
+	return fmt.Sprintf("%v: %v", wrap(p.Key, QM), formatFloatJSON(p.Val))
+}
+
+// JBoolPair is a key/bool-value pair, rendered without quotes.
+type JBoolPair struct {
+	Key string
+	Val bool
+}
+
+// NewJBool builds a JBoolPair.
+func NewJBool(key string, v bool) JBoolPair {
+	return JBoolPair{Key: key, Val: v}
+}
+
+func (p JBoolPair) GetKey() string {
+	return p.Key
+}
+
+func (p JBoolPair) String() string {
+	return fmt.Sprintf("%v: %v", wrap(p.Key, QM), strconv.FormatBool(p.Val))
+}
+
+// JTimePair is a key/time-value pair, rendered as a quoted string
+// formatted with Layout.
+type JTimePair struct {
+	Key    string
+	Val    time.Time
+	Layout string
+}
+
+// NewJTime builds a JTimePair that renders t formatted with layout.
+func NewJTime(key string, t time.Time, layout string) JTimePair {
+	return JTimePair{Key: key, Val: t, Layout: layout}
+}
+
+func (p JTimePair) GetKey() string {
+	return p.Key
+}
+
+func (p JTimePair) String() string {
+	return fmt.Sprintf("%v: %v", wrap(p.Key, QM), wrap(p.Val.Format(p.Layout), QM))
+}
+
+// JRawPair is a key/value pair whose value is inserted verbatim,
+// unquoted and unescaped. The caller is responsible for ensuring Raw is
+// valid JSON.
+type JRawPair struct {
+	Key string
+	Raw []byte
+}
+
+// NewJRaw builds a JRawPair.
+func NewJRaw(key string, raw []byte) JRawPair {
+	return JRawPair{Key: key, Raw: raw}
+}
+
+func (p JRawPair) GetKey() string {
+	return p.Key
+}
+
+func (p JRawPair) String() string {
+	return fmt.Sprintf("%v: %v", wrap(p.Key, QM), string(p.Raw))
+}